@@ -0,0 +1,172 @@
+// Package colorscheme loads the colour palette go-fetch renders with,
+// either from a built-in scheme compiled into the binary or from a
+// TOML/JSON file under $XDG_CONFIG_HOME/go-fetch/colorschemes, mirroring
+// gotop's colorschemes layout.
+package colorscheme
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/muesli/termenv"
+)
+
+// Colorscheme is the palette go-fetch renders its output with. Each field
+// holds either a named ANSI index ("4") or a hex colour ("#88c0d0");
+// termenv maps either down to whatever the terminal actually supports.
+type Colorscheme struct {
+	Label     string     `toml:"label" json:"label"`
+	Value     string     `toml:"value" json:"value"`
+	Host      string     `toml:"host" json:"host"`
+	Separator string     `toml:"separator" json:"separator"`
+	Block     [16]string `toml:"block" json:"block"`
+}
+
+// builtin holds the colorschemes compiled into the binary, used when no
+// matching file is found under the user's config directory, and as the
+// fallback for unknown --colorscheme names.
+var builtin = map[string]Colorscheme{
+	"default": {
+		Label:     "5",
+		Value:     "4",
+		Host:      "4",
+		Separator: "7",
+		Block:     [16]string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15"},
+	},
+	"nord": {
+		Label:     "#81a1c1",
+		Value:     "#d8dee9",
+		Host:      "#88c0d0",
+		Separator: "#4c566a",
+		Block: [16]string{
+			"#3b4252", "#bf616a", "#a3be8c", "#ebcb8b",
+			"#81a1c1", "#b48ead", "#88c0d0", "#e5e9f0",
+			"#4c566a", "#bf616a", "#a3be8c", "#ebcb8b",
+			"#81a1c1", "#b48ead", "#8fbcbb", "#eceff4",
+		},
+	},
+	"monokai": {
+		Label:     "#f92672",
+		Value:     "#f8f8f2",
+		Host:      "#a6e22e",
+		Separator: "#75715e",
+		Block: [16]string{
+			"#272822", "#f92672", "#a6e22e", "#f4bf75",
+			"#66d9ef", "#ae81ff", "#a1efe4", "#f8f8f2",
+			"#75715e", "#f92672", "#a6e22e", "#f4bf75",
+			"#66d9ef", "#ae81ff", "#a1efe4", "#f9f8f5",
+		},
+	},
+	"solarized": {
+		Label:     "#268bd2",
+		Value:     "#657b83",
+		Host:      "#2aa198",
+		Separator: "#93a1a1",
+		Block: [16]string{
+			"#073642", "#dc322f", "#859900", "#b58900",
+			"#268bd2", "#d33682", "#2aa198", "#eee8d5",
+			"#002b36", "#cb4b16", "#586e75", "#657b83",
+			"#839496", "#6c71c4", "#93a1a1", "#fdf6e3",
+		},
+	},
+	"solarized_dark": {
+		Label:     "#268bd2",
+		Value:     "#839496",
+		Host:      "#2aa198",
+		Separator: "#586e75",
+		Block: [16]string{
+			"#073642", "#dc322f", "#859900", "#b58900",
+			"#268bd2", "#d33682", "#2aa198", "#eee8d5",
+			"#002b36", "#cb4b16", "#586e75", "#657b83",
+			"#839496", "#6c71c4", "#93a1a1", "#fdf6e3",
+		},
+	},
+}
+
+// Names returns the list of built-in colorscheme names.
+func Names() []string {
+	names := make([]string, 0, len(builtin))
+	for name := range builtin {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Load returns the colorscheme registered under name. It first looks for
+// name.toml or name.json under $XDG_CONFIG_HOME/go-fetch/colorschemes (or
+// ~/.config/go-fetch/colorschemes if XDG_CONFIG_HOME is unset), falling
+// back to a built-in scheme of the same name, and finally to "default".
+func Load(name string) (Colorscheme, error) {
+	if name == "" {
+		name = "default"
+	}
+
+	if scheme, ok, err := loadFile(name); err != nil {
+		return Colorscheme{}, err
+	} else if ok {
+		return scheme, nil
+	}
+
+	if scheme, ok := builtin[name]; ok {
+		return scheme, nil
+	}
+
+	return Colorscheme{}, fmt.Errorf("unknown colorscheme %q", name)
+}
+
+// loadFile looks for a user-supplied colorscheme file named name.toml or
+// name.json. The bool result reports whether a matching file was found.
+func loadFile(name string) (Colorscheme, bool, error) {
+	dir := configDir()
+	if dir == "" {
+		return Colorscheme{}, false, nil
+	}
+
+	for _, ext := range []string{".toml", ".json"} {
+		path := filepath.Join(dir, "colorschemes", name+ext)
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return Colorscheme{}, false, err
+		}
+
+		var scheme Colorscheme
+		if ext == ".toml" {
+			err = toml.Unmarshal(data, &scheme)
+		} else {
+			err = json.Unmarshal(data, &scheme)
+		}
+		if err != nil {
+			return Colorscheme{}, false, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		return scheme, true, nil
+	}
+
+	return Colorscheme{}, false, nil
+}
+
+// configDir returns the go-fetch config directory, honouring
+// $XDG_CONFIG_HOME and falling back to ~/.config.
+func configDir() string {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "go-fetch")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "go-fetch")
+}
+
+// Colourise wraps s in the given colour value (an ANSI index or hex
+// colour), downgrading gracefully to whatever colour depth the current
+// terminal supports via termenv.
+func Colourise(s string, colorValue string) string {
+	profile := termenv.ColorProfile()
+	return termenv.String(s).Foreground(profile.Color(colorValue)).String()
+}
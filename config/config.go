@@ -0,0 +1,96 @@
+// Package config loads go-fetch's user configuration, which controls
+// which info modules are displayed, in what order, and with what
+// per-module options.
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// DefaultModules is the module order go-fetch uses when no config file
+// is present.
+var DefaultModules = []string{
+	"host", "os", "kernel", "uptime", "shell", "cpu", "gpu", "memory", "packages",
+}
+
+// Memory holds per-module options for the "memory" module.
+type Memory struct {
+	// Unit is the binary unit memory values are rendered in: "auto"
+	// (the default, same as go-fetch's existing formatBytes behaviour),
+	// "MiB", or "GiB".
+	Unit string `toml:"unit"`
+}
+
+// Uptime holds per-module options for the "uptime" module.
+type Uptime struct {
+	// Format is "pretty" (the default, "Xd Yh Zm") or "seconds" (raw
+	// uptime in seconds).
+	Format string `toml:"format"`
+}
+
+// Shell holds per-module options for the "shell" module.
+type Shell struct {
+	// ShowVersion appends the shell's --version output when true.
+	ShowVersion bool `toml:"show_version"`
+}
+
+// Display holds the module list and per-module options under [display].
+type Display struct {
+	Modules []string `toml:"modules"`
+	Memory  Memory   `toml:"memory"`
+	Uptime  Uptime   `toml:"uptime"`
+	Shell   Shell    `toml:"shell"`
+}
+
+// Config is the full go-fetch configuration.
+type Config struct {
+	Display Display `toml:"display"`
+}
+
+// Default returns the configuration go-fetch uses when no config file is
+// found.
+func Default() Config {
+	return Config{Display: Display{Modules: DefaultModules}}
+}
+
+// Load reads ~/.config/go-fetch/config.toml (or
+// $XDG_CONFIG_HOME/go-fetch/config.toml), returning Default() if it does
+// not exist.
+func Load() (Config, error) {
+	path, err := path()
+	if err != nil {
+		return Config{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Default(), nil
+	}
+	if err != nil {
+		return Config{}, err
+	}
+
+	cfg := Default()
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return Config{}, err
+	}
+	if len(cfg.Display.Modules) == 0 {
+		cfg.Display.Modules = DefaultModules
+	}
+	return cfg, nil
+}
+
+// path returns the location of go-fetch's config.toml.
+func path() (string, error) {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "go-fetch", "config.toml"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "go-fetch", "config.toml"), nil
+}
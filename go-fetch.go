@@ -3,40 +3,44 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
-	"os/exec"
-	"path/filepath"
 	"regexp"
-	"runtime"
 	"strings"
-	"time"
 
 	"github.com/jaypipes/ghw"
+	"github.com/machin0r/go-fetch/colorscheme"
+	"github.com/machin0r/go-fetch/config"
+	"github.com/machin0r/go-fetch/logos"
+	"github.com/machin0r/go-fetch/remote"
+	"github.com/machin0r/go-fetch/sysinfo"
 	"github.com/muesli/termenv"
-	"github.com/shirou/gopsutil/v3/cpu"
-	"github.com/shirou/gopsutil/v3/host"
-	"github.com/shirou/gopsutil/v3/mem"
+	"gopkg.in/yaml.v3"
 )
 
-// ANSI color codes for output formatting
-const (
-	text     = "\033[34m"
-	category = "\033[95m"
-	hostcol  = "\033[34m"
-	reset    = "\033[0m"
-)
+// maxRemoteWorkers caps how many hosts are gathered concurrently when
+// --remote is given a long host list.
+const maxRemoteWorkers = 8
 
-// colourise wraps the given text with the specified colour code and reset code.
-// This function is used to apply consistent colouring throughout the output.
-func colourise(text string, color string) string {
-	return color + text + reset
-}
+// stderr is the process's real stderr, captured before main redirects
+// os.Stderr to go-fetch.log, so user-facing warnings and errors still
+// reach the terminal instead of disappearing into the log file.
+var stderr = os.Stderr
 
 // main is the entry point of the application. It controls the gathering
 // and display of system information, handling any errors that occur during
 // the process.
 func main() {
+	noLogo := flag.Bool("no-logo", false, "disable the ASCII-art logo")
+	logoName := flag.String("logo", "", "force a specific logo (e.g. debian, arch, tux)")
+	output := flag.String("output", "human", "output format: human, json, yaml")
+	flag.StringVar(output, "o", "human", "shorthand for --output")
+	remoteHosts := flag.String("remote", "", "comma-separated list of hosts to fetch over SSH instead of the local machine")
+	colorschemeName := flag.String("colorscheme", "default", "colorscheme to render with (default, nord, monokai, solarized, solarized_dark, or a custom scheme name)")
+	flag.Parse()
+
 	// Redirect stderr to a log file
 	logFile, err := os.OpenFile("go-fetch.log", os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
 	if err == nil {
@@ -47,210 +51,222 @@ func main() {
 	// Disable ghw warnings
 	ghw.WithDisableWarnings()
 
-	// Gather system information
-	hostname, _ := os.Hostname()
-	username := os.Getenv("USER")
-	osInfo, _ := host.Info()
-	memInfo, _ := mem.VirtualMemory()
-	cpuModel, logicalCores, cpuSpeed := getCPUInfo()
-
-	// Attempt to get package count, defaulting to -1 if unsuccessful
-	packageCount, err := getPackageCount()
+	scheme, err := colorscheme.Load(*colorschemeName)
 	if err != nil {
-		packageCount = -1 // Indicate error
+		fmt.Fprintf(stderr, "go-fetch: %s, falling back to default\n", err)
+		scheme, _ = colorscheme.Load("default")
 	}
 
-	// Attempt to get GPU information, defaulting to "None" if unsuccessful
-	gpu, err := getGPUInfo()
+	cfg, err := config.Load()
 	if err != nil {
-		gpu = "None"
+		fmt.Fprintf(stderr, "go-fetch: %s, falling back to default modules\n", err)
+		cfg = config.Default()
 	}
 
-	// Print the username and hostname
-	fmt.Printf("\x1b[1m%s@%s\x1b[0m\n", colourise(username, hostcol), colourise(hostname, hostcol))
-
-	// Prepare the information to be displayed
-	info := []struct {
-		label string
-		value string
-	}{
-		{"Hostname", hostname},
-		{"OS", fmt.Sprintf("%s %s", osInfo.Platform, osInfo.PlatformVersion)},
-		{"Kernel", osInfo.KernelVersion},
-		{"Uptime", formatUptime(osInfo.Uptime)},
-		{"Shell", filepath.Base(os.Getenv("SHELL"))},
-		{"CPU", fmt.Sprintf("%s (%d cores @ %.2f GHz)", cpuModel, logicalCores, cpuSpeed)},
-		{"GPU", fmt.Sprintf("%s", gpu)},
-		{"Memory", fmt.Sprintf("%s / %s", formatBytes(memInfo.Used), formatBytes(memInfo.Total))},
+	if *remoteHosts != "" {
+		runRemote(strings.Split(*remoteHosts, ","), *output, *noLogo, *logoName, scheme, cfg)
+		return
 	}
 
-	// Add package count information if available
-	if packageCount >= 0 {
-		info = append(info, struct{ label, value string }{"Packages", fmt.Sprintf("%d", packageCount)})
-	} else {
-		info = append(info, struct{ label, value string }{"Packages", fmt.Sprintf("Unable to determine (%s)", err)})
+	info, err := sysinfo.Collect()
+	if err != nil {
+		fmt.Fprintf(stderr, "go-fetch: failed to gather system information: %s\n", err)
+		os.Exit(1)
 	}
 
-	// Find the longest label for alignment
-	maxLabelLength := 0
-	for _, item := range info {
-		if len(item.label) > maxLabelLength {
-			maxLabelLength = len(item.label)
-		}
+	switch *output {
+	case "json":
+		printJSON(info)
+	case "yaml":
+		printYAML(info)
+	default:
+		printHuman(info, *noLogo, *logoName, scheme, cfg)
 	}
+}
 
-	// Print aligned and colored information
-	for _, item := range info {
-		fmt.Printf("%s%-*s %s\n",
-			colourise(item.label, category),
-			maxLabelLength-len(item.label),
-			"",
-			colourise(item.value, text))
+// runRemote gathers system info from each host in hosts over SSH, using a
+// bounded worker pool, and renders each reachable host's block in turn
+// followed by a "N/M hosts reached" summary footer.
+func runRemote(hosts []string, output string, noLogo bool, logoName string, scheme colorscheme.Colorscheme, cfg config.Config) {
+	for i, host := range hosts {
+		hosts[i] = strings.TrimSpace(host)
 	}
-	// Display color blocks at the end of the output
-	printColorBlocks()
-}
 
-// formatUptime converts the uptime in seconds to a human-readable string
-// in the format of "Xd Yh Zm" (days, hours, minutes).
-func formatUptime(uptime uint64) string {
-	duration := time.Duration(uptime) * time.Second
-	days := int(duration.Hours() / 24)
-	hours := int(duration.Hours()) % 24
-	minutes := int(duration.Minutes()) % 60
+	results := remote.Gather(hosts, maxRemoteWorkers)
 
-	return fmt.Sprintf("%dd %dh %dm", days, hours, minutes)
-}
+	reached := 0
+	for i, result := range results {
+		if result.Err != nil {
+			fmt.Fprintf(stderr, "go-fetch: %s: %s\n", hosts[i], result.Err)
+			continue
+		}
+		reached++
 
-// formatBytes converts bytes to a human-readable string with appropriate unit suffix.
-// It uses binary units (KiB, MiB, GiB, etc.)
-func formatBytes(bytes uint64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
+		switch output {
+		case "json":
+			printJSON(result.Info)
+		case "yaml":
+			printYAML(result.Info)
+		default:
+			printHuman(result.Info, noLogo, logoName, scheme, cfg)
+		}
 	}
-	div, exp := uint64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
+
+	fmt.Printf("%d/%d hosts reached\n", reached, len(hosts))
+}
+
+// printJSON writes info to stdout as indented JSON.
+func printJSON(info sysinfo.SystemInfo) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(info); err != nil {
+		fmt.Fprintf(stderr, "go-fetch: failed to encode JSON: %s\n", err)
+		os.Exit(1)
 	}
-	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
 
-// getPackageCount attempts to count the number of installed packages on the system.
-// It supports multiple package managers (dpkg, pacman, rpm) and tries to detect
-// the appropriate one based on the OS and available commands.
-func getPackageCount() (int, error) {
-	var cmd *exec.Cmd
-	osInfo, err := host.Info()
+// printYAML writes info to stdout as YAML.
+func printYAML(info sysinfo.SystemInfo) {
+	out, err := yaml.Marshal(info)
 	if err != nil {
-		return 0, err
+		fmt.Fprintf(stderr, "go-fetch: failed to encode YAML: %s\n", err)
+		os.Exit(1)
 	}
+	os.Stdout.Write(out)
+}
 
-	// Determine the appropriate package manager command based on the OS
-	switch runtime.GOOS {
-	case "linux":
-		switch osInfo.Platform {
-		case "debian", "ubuntu":
-			cmd = exec.Command("dpkg", "--get-selections")
-		case "arch":
-			cmd = exec.Command("pacman", "-Q")
-		case "fedora", "centos", "rhel":
-			cmd = exec.Command("rpm", "-qa")
-		default:
-			// Fallback detection for unknown distributions
-			if _, err := exec.LookPath("dpkg"); err == nil {
-				cmd = exec.Command("dpkg", "--get-selections")
-			} else if _, err := exec.LookPath("pacman"); err == nil {
-				cmd = exec.Command("pacman", "-Q")
-			} else if _, err := exec.LookPath("rpm"); err == nil {
-				cmd = exec.Command("rpm", "-qa")
-			} else {
-				return 0, fmt.Errorf("unsupported Linux distribution")
-			}
+// printHuman renders info in go-fetch's usual colourised, logo-accompanied
+// format, showing the modules listed in cfg.Display.Modules in order.
+func printHuman(info sysinfo.SystemInfo, noLogo bool, logoName string, scheme colorscheme.Colorscheme, cfg config.Config) {
+	username := os.Getenv("USER")
+
+	var rows []infoRow
+	for _, name := range cfg.Display.Modules {
+		render, ok := moduleRegistry[name]
+		if !ok {
+			fmt.Fprintf(stderr, "go-fetch: unknown module %q in config, skipping\n", name)
+			continue
 		}
-	default:
-		return 0, fmt.Errorf("Unsupported OS for package counting")
+		modRows, err := render(info, cfg)
+		if err != nil {
+			continue
+		}
+		rows = append(rows, modRows...)
 	}
 
-	// Execute the command and count the lines of output
-	output, err := cmd.Output()
-	if err != nil {
-		return 0, err
+	// Find the longest label for alignment
+	maxLabelLength := 0
+	for _, row := range rows {
+		if len(row.label) > maxLabelLength {
+			maxLabelLength = len(row.label)
+		}
 	}
 
-	return len(strings.Split(string(output), "\n")) - 1, nil
+	// Render the header and info rows as plain lines, then pair them up
+	// with the logo so the two columns can be printed side by side.
+	lines := make([]string, 0, len(rows)+1)
+	lines = append(lines, fmt.Sprintf("\x1b[1m%s@%s\x1b[0m",
+		colorscheme.Colourise(username, scheme.Host),
+		colorscheme.Colourise(info.Host, scheme.Host)))
+	for _, row := range rows {
+		gap := strings.Repeat(" ", maxLabelLength-len(row.label)+1)
+		lines = append(lines, fmt.Sprintf("%s%s%s",
+			colorscheme.Colourise(row.label, scheme.Label),
+			colorscheme.Colourise(gap, scheme.Separator),
+			colorscheme.Colourise(row.value, scheme.Value)))
+	}
+
+	printWithLogo(lines, noLogo, logoName, platformOf(info.OS))
+
+	// Display color blocks at the end of the output
+	printColorBlocks(scheme)
 }
 
-// getCPUInfo retrieves CPU information including model name, core count, and clock speed.
-// It falls back to runtime.NumCPU() for core count if gopsutil fails to provide the information.
-func getCPUInfo() (model string, cores int, speed float64) {
-	cpuInfo, err := cpu.Info()
-	if err != nil || len(cpuInfo) == 0 {
-		// Fallback to runtime package for core count
-		cores = runtime.NumCPU()
-		model = "Unknown"
-		speed = 0.0
+// platformOf extracts the distro/OS key (e.g. "debian") from the combined
+// "<platform> <version>" string sysinfo.SystemInfo.OS carries, for use as
+// a logo lookup key.
+func platformOf(os string) string {
+	if i := strings.IndexByte(os, ' '); i >= 0 {
+		return os[:i]
+	}
+	return os
+}
+
+// printWithLogo prints infoLines to the right of a distro ASCII logo,
+// selected from osInfo.Platform unless overridden by logoName. If noLogo
+// is set, or the combined width would not improve readability, it falls
+// back to printing infoLines alone. Lines beyond the shorter of the two
+// columns are padded with blanks so the columns stay aligned.
+func printWithLogo(infoLines []string, noLogo bool, logoName string, platform string) {
+	if noLogo {
+		for _, line := range infoLines {
+			fmt.Println(line)
+		}
 		return
 	}
 
-	model = cpuInfo[0].ModelName
-	cores = runtime.NumCPU()      // Use runtime.NumCPU() for consistent logical core count
-	speed = cpuInfo[0].Mhz / 1000 // Convert to GHz
+	name := platform
+	if logoName != "" {
+		name = logoName
+	}
+	logo, _ := logos.Get(name)
 
-	// If cores is 0, fallback to runtime package
-	if cores == 0 {
-		cores = runtime.NumCPU()
+	rows := len(logo.Lines)
+	if len(infoLines) > rows {
+		rows = len(infoLines)
 	}
 
-	return
-}
+	for i := 0; i < rows; i++ {
+		logoLine := ""
+		if i < len(logo.Lines) {
+			logoLine = logo.Lines[i]
+		}
+		pad := logo.Width - visibleLen(logoLine)
+		if pad < 0 {
+			pad = 0
+		}
+
+		infoLine := ""
+		if i < len(infoLines) {
+			infoLine = infoLines[i]
+		}
 
-// cleanGPUName removes model number prefixes from GPU names to provide a cleaner output.
-// It uses a regular expression to extract the main part of the GPU name.
-func cleanGPUName(name string) string {
-	re := regexp.MustCompile(`^[A-Z0-9]+\s*\[(.+)\]$`)
-	matches := re.FindStringSubmatch(name)
-	if len(matches) > 1 {
-		return strings.TrimSpace(matches[1])
+		fmt.Printf("%s%-*s  %s\n", logoLine, pad, "", infoLine)
 	}
-	return name
 }
 
-// getGPUInfo attempts to retrieve information about the system's GPU.
-// It returns the name of the first GPU found or an error if no GPU is detected.
-func getGPUInfo() (string, error) {
-	gpu, err := ghw.GPU()
-	if err != nil {
-		return "", err
+// ansiEscape matches ANSI colour escape sequences so visibleLen can exclude
+// them when computing how much padding a line needs.
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// visibleLen returns the length of s as it would appear on screen, i.e.
+// with ANSI escape sequences stripped out.
+func visibleLen(s string) int {
+	return len(ansiEscape.ReplaceAllString(s, ""))
+}
+
+// formatBytes converts bytes to a human-readable string with appropriate unit suffix.
+// It uses binary units (KiB, MiB, GiB, etc.)
+func formatBytes(bytes uint64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
 	}
-	if len(gpu.GraphicsCards) > 0 {
-		card := gpu.GraphicsCards[0]
-		if card.DeviceInfo != nil && card.DeviceInfo.Product != nil {
-			return cleanGPUName(card.DeviceInfo.Product.Name), nil
-		}
-		return fmt.Sprintf("Unknown GPU (Vendor: %s)", card.DeviceInfo.Vendor.Name), nil
+	div, exp := uint64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
 	}
-	return "None", nil
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
 
-// printColorBlocks displays a row of colored blocks at the end of the output.
-func printColorBlocks() {
+// printColorBlocks displays a row of colored blocks, one per scheme.Block
+// entry, at the end of the output.
+func printColorBlocks(scheme colorscheme.Colorscheme) {
 	p := termenv.ColorProfile()
 
-	// Use the 16 ANSI colors
-	colors := []termenv.Color{
-		p.Color("0"), // Black
-		p.Color("1"), // Red
-		p.Color("2"), // Green
-		p.Color("3"), // Yellow
-		p.Color("4"), // Blue
-		p.Color("5"), // Magenta
-		p.Color("6"), // Cyan
-	}
-
-	for _, color := range colors {
-		fmt.Print(termenv.String("   ").Background(color))
+	for _, value := range scheme.Block {
+		fmt.Print(termenv.String("   ").Background(p.Color(value)))
 	}
 	fmt.Println()
 }
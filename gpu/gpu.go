@@ -0,0 +1,269 @@
+// Package gpu enumerates every graphics card present on the host, with
+// vendor, driver and VRAM detail where the platform exposes it.
+package gpu
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/jaypipes/ghw"
+)
+
+// GPU describes a single graphics card.
+type GPU struct {
+	Vendor        string `json:"vendor" yaml:"vendor"`
+	Product       string `json:"product" yaml:"product"`
+	DriverVersion string `json:"driver_version" yaml:"driver_version"`
+	VRAM          string `json:"vram" yaml:"vram"`
+	Bus           string `json:"bus" yaml:"bus"`
+}
+
+// Detect returns every GPU found on the host. It starts from ghw's PCI
+// enumeration (cross-platform) and supplements it with OS-specific detail
+// - amdgpu/nvidia-smi readouts on Linux, system_profiler on macOS, and WMI
+// on Windows - falling back to the bare ghw entry when those don't apply
+// or fail. An empty slice, not an error, signals "no GPU detected". The
+// OS-specific supplement step still runs when ghw found nothing, since on
+// Linux it can append a card of its own (e.g. nvidia-smi working inside a
+// container where ghw can't walk /sys/bus/pci).
+func Detect() []GPU {
+	cards, err := ghwCards()
+	if err != nil {
+		return nil
+	}
+
+	switch runtime.GOOS {
+	case "linux":
+		cards = supplementLinux(cards)
+	case "darwin":
+		supplementDarwin(cards)
+	case "windows":
+		supplementWindows(cards)
+	}
+
+	return cards
+}
+
+// ghwCards returns one GPU per card ghw's PCI enumeration reports, with
+// Vendor/Product/Bus filled in and DriverVersion/VRAM left for the
+// OS-specific supplement step.
+func ghwCards() ([]GPU, error) {
+	info, err := ghw.GPU()
+	if err != nil {
+		return nil, err
+	}
+
+	cards := make([]GPU, 0, len(info.GraphicsCards))
+	for _, card := range info.GraphicsCards {
+		g := GPU{Bus: card.Address}
+		if card.DeviceInfo != nil {
+			if card.DeviceInfo.Vendor != nil {
+				g.Vendor = card.DeviceInfo.Vendor.Name
+			}
+			if card.DeviceInfo.Product != nil {
+				g.Product = cleanProductName(card.DeviceInfo.Product.Name)
+			}
+		}
+		cards = append(cards, g)
+	}
+	return cards, nil
+}
+
+// cleanProductName removes model number prefixes from GPU names to
+// provide a cleaner output, e.g. "1002 [Radeon RX 6700 XT]" -> "Radeon RX
+// 6700 XT".
+func cleanProductName(name string) string {
+	re := regexp.MustCompile(`^[A-Z0-9]+\s*\[(.+)\]$`)
+	matches := re.FindStringSubmatch(name)
+	if len(matches) > 1 {
+		return strings.TrimSpace(matches[1])
+	}
+	return name
+}
+
+// supplementLinux fills in VRAM for amdgpu cards from sysfs, and
+// vendor/driver/VRAM for any card nvidia-smi reports. It returns the
+// (possibly longer, if nvidia-smi found a card ghw didn't) slice, since
+// supplementNVIDIA can append to it.
+func supplementLinux(cards []GPU) []GPU {
+	supplementAMDVRAM(cards)
+	return supplementNVIDIA(cards)
+}
+
+// supplementAMDVRAM reads /sys/class/drm/card*/device/mem_info_vram_total,
+// which amdgpu exposes, and records it against the matching card by PCI bus
+// address.
+func supplementAMDVRAM(cards []GPU) {
+	matches, err := filepath.Glob("/sys/class/drm/card*/device/mem_info_vram_total")
+	if err != nil {
+		return
+	}
+
+	for _, path := range matches {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		bytes, err := strconv.ParseUint(strings.TrimSpace(string(raw)), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		addr, err := os.Readlink(filepath.Dir(path))
+		if err != nil {
+			continue
+		}
+
+		for i := range cards {
+			if cards[i].Bus != "" && strings.Contains(addr, cards[i].Bus) {
+				cards[i].VRAM = formatBytes(bytes)
+			}
+		}
+	}
+}
+
+// supplementNVIDIA shells out to nvidia-smi, when present, and merges its
+// name/driver/VRAM readout into the ghw card with the matching PCI bus
+// address (or appends it if ghw didn't already enumerate it, e.g. on a
+// hybrid laptop where ghw's PCI walk missed the discrete GPU).
+func supplementNVIDIA(cards []GPU) []GPU {
+	if _, err := exec.LookPath("nvidia-smi"); err != nil {
+		return cards
+	}
+
+	out, err := exec.Command("nvidia-smi", "--query-gpu=name,driver_version,memory.total,pci.bus_id", "--format=csv,noheader").Output()
+	if err != nil {
+		return cards
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(string(out))).ReadAll()
+	if err != nil {
+		return cards
+	}
+
+	for _, row := range rows {
+		if len(row) != 4 {
+			continue
+		}
+		name := strings.TrimSpace(row[0])
+		driver := strings.TrimSpace(row[1])
+		vram := strings.TrimSpace(row[2])
+		bus := strings.TrimSpace(row[3])
+
+		matched := false
+		for i := range cards {
+			if cards[i].Bus != "" && busSuffix(cards[i].Bus) == busSuffix(bus) {
+				cards[i].Vendor = "NVIDIA"
+				cards[i].Product = name
+				cards[i].DriverVersion = driver
+				cards[i].VRAM = vram
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			cards = append(cards, GPU{Vendor: "NVIDIA", Product: name, DriverVersion: driver, VRAM: vram, Bus: bus})
+		}
+	}
+
+	return cards
+}
+
+// busSuffix returns the "<bus>:<device>.<function>" tail of a PCI address,
+// dropping the domain segment. Domains are zero-padded inconsistently
+// between ghw ("0000:01:00.0") and nvidia-smi ("00000000:01:00.0"), so
+// comparing full addresses would never match; the bus/device/function
+// tail is what actually identifies the card.
+func busSuffix(addr string) string {
+	parts := strings.Split(addr, ":")
+	if len(parts) < 2 {
+		return addr
+	}
+	return strings.Join(parts[len(parts)-2:], ":")
+}
+
+// supplementDarwin fills in driver/VRAM detail from `system_profiler
+// SPDisplaysDataType -json`.
+func supplementDarwin(cards []GPU) {
+	out, err := exec.Command("system_profiler", "SPDisplaysDataType", "-json").Output()
+	if err != nil {
+		return
+	}
+
+	var report struct {
+		Displays []struct {
+			Name       string `json:"sppci_model"`
+			VRAM       string `json:"spdisplays_vram"`
+			DriverVer  string `json:"spdisplays_driver_version"`
+			VendorName string `json:"spdisplays_vendor"`
+		} `json:"SPDisplaysDataType"`
+	}
+	if err := json.Unmarshal(out, &report); err != nil {
+		return
+	}
+
+	for i, display := range report.Displays {
+		if i >= len(cards) {
+			break
+		}
+		if display.Name != "" {
+			cards[i].Product = display.Name
+		}
+		if display.VendorName != "" {
+			cards[i].Vendor = display.VendorName
+		}
+		cards[i].VRAM = display.VRAM
+		cards[i].DriverVersion = display.DriverVer
+	}
+}
+
+// supplementWindows fills in driver/VRAM detail from the Win32_VideoController
+// WMI class via PowerShell, since gopsutil already pulls in StackExchange/wmi.
+func supplementWindows(cards []GPU) {
+	out, err := exec.Command("powershell", "-NoProfile", "-Command",
+		"Get-CimInstance Win32_VideoController | Select-Object Name,DriverVersion,AdapterRAM | ConvertTo-Json").Output()
+	if err != nil {
+		return
+	}
+
+	var controllers []struct {
+		Name          string
+		DriverVersion string
+		AdapterRAM    uint64
+	}
+	if err := json.Unmarshal(out, &controllers); err != nil {
+		return
+	}
+
+	for i, c := range controllers {
+		if i >= len(cards) {
+			break
+		}
+		cards[i].Product = c.Name
+		cards[i].DriverVersion = c.DriverVersion
+		cards[i].VRAM = formatBytes(c.AdapterRAM)
+	}
+}
+
+// formatBytes converts bytes to a human-readable string with appropriate
+// unit suffix, using binary units (KiB, MiB, GiB, etc.)
+func formatBytes(bytes uint64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := uint64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
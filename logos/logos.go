@@ -0,0 +1,123 @@
+// Package logos provides small ASCII-art renditions of common OS logos,
+// in the style of neofetch/bitric, for use alongside a system info block.
+package logos
+
+const (
+	red     = "\033[31m"
+	green   = "\033[32m"
+	yellow  = "\033[33m"
+	blue    = "\033[34m"
+	magenta = "\033[35m"
+	cyan    = "\033[36m"
+	white   = "\033[37m"
+	reset   = "\033[0m"
+)
+
+// Logo is a multi-line ASCII-art block and the display width it occupies,
+// so callers can pad it consistently when printing alongside other text.
+type Logo struct {
+	Lines []string
+	Width int
+}
+
+// logos maps a platform key (as seen in osInfo.Platform, or a user-supplied
+// --logo override) to its ASCII art. The fallback entry is "tux".
+var logos = map[string]Logo{
+	"debian": {
+		Width: 16,
+		Lines: []string{
+			red + "  _____        " + reset,
+			red + " /  __ \\       " + reset,
+			red + "|  /    |      " + reset,
+			red + "|  \\___-       " + reset,
+			red + "-_             " + reset,
+			red + "  --_          " + reset,
+		},
+	},
+	"ubuntu": {
+		Width: 16,
+		Lines: []string{
+			red + "         _     " + reset,
+			red + "     ---(_)   " + reset,
+			red + " _/  ---  \\   " + reset,
+			red + "(_) |   |     " + reset,
+			red + "  \\  --- _/   " + reset,
+			red + "     ---(_)   " + reset,
+		},
+	},
+	"arch": {
+		Width: 16,
+		Lines: []string{
+			cyan + "      /\\       " + reset,
+			cyan + "     /  \\      " + reset,
+			cyan + "    /\\   \\     " + reset,
+			cyan + "   /      \\    " + reset,
+			cyan + "  /   ,,   \\   " + reset,
+			cyan + " /   |  |  -\\  " + reset,
+		},
+	},
+	"fedora": {
+		Width: 16,
+		Lines: []string{
+			blue + "      _____    " + reset,
+			blue + "     /   __)\\  " + reset,
+			blue + "     |  /  \\ \\ " + reset,
+			blue + " _____| |__/ / " + reset,
+			blue + "/ ________   \\ " + reset,
+			blue + "\\               " + reset,
+		},
+	},
+	"darwin": {
+		Width: 16,
+		Lines: []string{
+			green + "      .:'     " + reset,
+			green + "   _ :'_      " + reset,
+			green + "(_)'-'  |     " + reset,
+			green + "/ /-._.'      " + reset,
+			green + "\\ \\           " + reset,
+			green + " '-'          " + reset,
+		},
+	},
+	"windows": {
+		Width: 16,
+		Lines: []string{
+			blue + "  |       |   " + reset,
+			blue + "--+-------+-- " + reset,
+			blue + "  |       |   " + reset,
+			blue + "--+-------+-- " + reset,
+			blue + "  |       |   " + reset,
+			blue + "  |       |   " + reset,
+		},
+	},
+	"tux": {
+		Width: 16,
+		Lines: []string{
+			white + "   .--.       " + reset,
+			white + "  |o_o |      " + reset,
+			white + "  |:_/ |      " + reset,
+			white + " //   \\ \\     " + reset,
+			white + "(|     | )    " + reset,
+			white + "/'\\_   _/`\\   " + reset,
+		},
+	},
+}
+
+// Get returns the logo registered for name, falling back to the generic
+// "tux" logo when name is unrecognised. The bool result reports whether
+// name matched a known logo exactly.
+func Get(name string) (Logo, bool) {
+	logo, ok := logos[name]
+	if ok {
+		return logo, true
+	}
+	return logos["tux"], false
+}
+
+// Names returns the list of logo keys that can be passed to --logo.
+func Names() []string {
+	names := make([]string, 0, len(logos))
+	for name := range logos {
+		names = append(names, name)
+	}
+	return names
+}
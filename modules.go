@@ -0,0 +1,234 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/machin0r/go-fetch/config"
+	"github.com/machin0r/go-fetch/gpu"
+	"github.com/machin0r/go-fetch/sysinfo"
+	"github.com/shirou/gopsutil/v3/disk"
+	"golang.org/x/term"
+)
+
+// infoRow is a single label/value line of go-fetch's human-readable
+// output.
+type infoRow struct {
+	label string
+	value string
+}
+
+// moduleFunc renders one config-selected module's rows from a gathered
+// SystemInfo. Returning an error drops the module from the output
+// entirely, so an optional module (e.g. battery on a desktop with none)
+// can fail closed rather than show a confusing value.
+type moduleFunc func(info sysinfo.SystemInfo, cfg config.Config) ([]infoRow, error)
+
+// moduleRegistry maps config.Display.Modules entries to their renderer.
+// Modules not listed here are reported as unknown and skipped.
+var moduleRegistry = map[string]moduleFunc{
+	"host":       moduleHost,
+	"os":         moduleOS,
+	"kernel":     moduleKernel,
+	"uptime":     moduleUptime,
+	"shell":      moduleShell,
+	"cpu":        moduleCPU,
+	"gpu":        moduleGPU,
+	"memory":     moduleMemory,
+	"packages":   modulePackages,
+	"battery":    moduleBattery,
+	"disk":       moduleDisk,
+	"ip":         moduleIP,
+	"resolution": moduleResolution,
+	"wm":         moduleWM,
+}
+
+func row(label, value string) ([]infoRow, error) {
+	return []infoRow{{label, value}}, nil
+}
+
+func moduleHost(info sysinfo.SystemInfo, _ config.Config) ([]infoRow, error) {
+	return row("Hostname", info.Host)
+}
+
+func moduleOS(info sysinfo.SystemInfo, _ config.Config) ([]infoRow, error) {
+	return row("OS", info.OS)
+}
+
+func moduleKernel(info sysinfo.SystemInfo, _ config.Config) ([]infoRow, error) {
+	return row("Kernel", info.Kernel)
+}
+
+// moduleUptime renders the uptime module per cfg.Display.Uptime.Format:
+// "pretty" (the default, matching sysinfo.SystemInfo.Uptime) or "seconds".
+func moduleUptime(info sysinfo.SystemInfo, cfg config.Config) ([]infoRow, error) {
+	if cfg.Display.Uptime.Format == "seconds" {
+		return row("Uptime", fmt.Sprintf("%d", info.UptimeSeconds))
+	}
+	return row("Uptime", info.Uptime)
+}
+
+// moduleShell renders the shell module, appending the shell's version
+// string when cfg.Display.Shell.ShowVersion is set.
+func moduleShell(info sysinfo.SystemInfo, cfg config.Config) ([]infoRow, error) {
+	value := info.Shell
+	if cfg.Display.Shell.ShowVersion {
+		if version, err := shellVersion(info.Shell); err == nil {
+			value = fmt.Sprintf("%s %s", value, version)
+		}
+	}
+	return row("Shell", value)
+}
+
+// shellVersion runs "<shell> --version" and returns its first line.
+func shellVersion(shell string) (string, error) {
+	out, err := exec.Command(shell, "--version").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.SplitN(string(out), "\n", 2)[0], nil
+}
+
+func moduleCPU(info sysinfo.SystemInfo, _ config.Config) ([]infoRow, error) {
+	return row("CPU", fmt.Sprintf("%s (%d cores @ %.2f GHz)", info.CPU.Model, info.CPU.Cores, info.CPU.GHz))
+}
+
+// moduleGPU renders one "GPU[n]" row per detected card, falling back to a
+// single "GPU: None" row when none were found.
+func moduleGPU(info sysinfo.SystemInfo, _ config.Config) ([]infoRow, error) {
+	if len(info.GPUs) == 0 {
+		return row("GPU", "None")
+	}
+
+	rows := make([]infoRow, len(info.GPUs))
+	for i, card := range info.GPUs {
+		label := "GPU"
+		if len(info.GPUs) > 1 {
+			label = fmt.Sprintf("GPU[%d]", i)
+		}
+		rows[i] = infoRow{label, gpuSummary(card)}
+	}
+	return rows, nil
+}
+
+// gpuSummary renders a single GPU's detail as a display string, e.g.
+// "NVIDIA GeForce RTX 4090 (driver 550.90.07, 24.0 GiB)".
+func gpuSummary(card gpu.GPU) string {
+	s := card.Product
+	if s == "" {
+		s = card.Vendor
+	}
+	var detail []string
+	if card.DriverVersion != "" {
+		detail = append(detail, fmt.Sprintf("driver %s", card.DriverVersion))
+	}
+	if card.VRAM != "" {
+		detail = append(detail, card.VRAM)
+	}
+	if len(detail) > 0 {
+		s = fmt.Sprintf("%s (%s)", s, strings.Join(detail, ", "))
+	}
+	return s
+}
+
+// moduleMemory renders the memory module per cfg.Display.Memory.Unit:
+// "auto" (the default, using formatBytes), "MiB", or "GiB".
+func moduleMemory(info sysinfo.SystemInfo, cfg config.Config) ([]infoRow, error) {
+	format := formatBytes
+	switch cfg.Display.Memory.Unit {
+	case "MiB":
+		format = func(b uint64) string { return fmt.Sprintf("%.1f MiB", float64(b)/(1<<20)) }
+	case "GiB":
+		format = func(b uint64) string { return fmt.Sprintf("%.1f GiB", float64(b)/(1<<30)) }
+	}
+	return row("Memory", fmt.Sprintf("%s / %s", format(info.Memory.Used), format(info.Memory.Total)))
+}
+
+// modulePackages renders the per-manager package counts as a single
+// display string, e.g. "1823 (dpkg), 42 (flatpak), 7 (snap)".
+func modulePackages(info sysinfo.SystemInfo, _ config.Config) ([]infoRow, error) {
+	if len(info.Packages) == 0 {
+		return row("Packages", "Unable to determine")
+	}
+	parts := make([]string, len(info.Packages))
+	for i, p := range info.Packages {
+		parts[i] = fmt.Sprintf("%d (%s)", p.Count, p.Manager)
+	}
+	return row("Packages", strings.Join(parts, ", "))
+}
+
+// moduleBattery reads the primary battery's charge from sysfs, the same
+// way go-fetch reads amdgpu VRAM in the gpu package. It errors (and so is
+// dropped from the output) on hosts with no battery.
+func moduleBattery(_ sysinfo.SystemInfo, _ config.Config) ([]infoRow, error) {
+	matches, err := filepath.Glob("/sys/class/power_supply/BAT*/capacity")
+	if err != nil || len(matches) == 0 {
+		return nil, fmt.Errorf("no battery found")
+	}
+
+	raw, err := os.ReadFile(matches[0])
+	if err != nil {
+		return nil, err
+	}
+
+	status := "Unknown"
+	if s, err := os.ReadFile(filepath.Join(filepath.Dir(matches[0]), "status")); err == nil {
+		status = strings.TrimSpace(string(s))
+	}
+
+	return row("Battery", fmt.Sprintf("%s%% (%s)", strings.TrimSpace(string(raw)), status))
+}
+
+// moduleDisk reports used/total space on the filesystem rooted at "/".
+func moduleDisk(_ sysinfo.SystemInfo, _ config.Config) ([]infoRow, error) {
+	usage, err := disk.Usage("/")
+	if err != nil {
+		return nil, err
+	}
+	return row("Disk", fmt.Sprintf("%s / %s", formatBytes(usage.Used), formatBytes(usage.Total)))
+}
+
+// moduleIP reports the host's local (non-loopback) IPv4 address.
+func moduleIP(_ sysinfo.SystemInfo, _ config.Config) ([]infoRow, error) {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.IsLoopback() {
+			continue
+		}
+		if ip4 := ipNet.IP.To4(); ip4 != nil {
+			return row("Local IP", ip4.String())
+		}
+	}
+	return nil, fmt.Errorf("no local IP found")
+}
+
+// moduleResolution reports the controlling terminal's size in columns and
+// rows, which is the closest go-fetch gets to "screen resolution" without
+// a display server dependency.
+func moduleResolution(_ sysinfo.SystemInfo, _ config.Config) ([]infoRow, error) {
+	cols, rows, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return nil, err
+	}
+	return row("Resolution", fmt.Sprintf("%dx%d", cols, rows))
+}
+
+// moduleWM reports the desktop environment or window manager from the
+// environment variables most of them set.
+func moduleWM(_ sysinfo.SystemInfo, _ config.Config) ([]infoRow, error) {
+	if de := os.Getenv("XDG_CURRENT_DESKTOP"); de != "" {
+		return row("WM/DE", de)
+	}
+	if de := os.Getenv("DESKTOP_SESSION"); de != "" {
+		return row("WM/DE", de)
+	}
+	return nil, fmt.Errorf("no WM/DE detected")
+}
@@ -0,0 +1,247 @@
+// Package packages counts installed packages across the package managers
+// present on the host, so go-fetch can report e.g. "1823 (dpkg), 42
+// (flatpak), 7 (snap)" instead of picking just one manager.
+package packages
+
+import (
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Count is the installed package count reported by a single manager.
+type Count struct {
+	Manager string
+	Count   int
+}
+
+// Counter counts the packages installed by one package manager. Detect
+// reports whether the manager is usable on this host (e.g. its binary is
+// on PATH); Count does the actual counting and should only be called when
+// Detect returned true.
+type Counter interface {
+	Manager() string
+	Detect() bool
+	Count() (int, error)
+}
+
+// counters lists every Counter go-fetch knows about. CountAll filters this
+// down to the ones that Detect() as present on the host.
+var counters = []Counter{
+	dpkgCounter{},
+	pacmanCounter{},
+	rpmCounter{},
+	brewCounter{},
+	macAppsCounter{},
+	freebsdPkgCounter{},
+	openbsdPkgCounter{},
+	chocoCounter{},
+	wingetCounter{},
+	appxCounter{},
+	flatpakCounter{},
+	snapCounter{},
+	nixCounter{},
+	xbpsCounter{},
+	apkCounter{},
+	emergeCounter{},
+}
+
+// CountAll runs every Counter applicable to the current OS whose manager
+// is detected on the host, and returns one Count per manager found. A
+// manager failing to report a count is skipped rather than aborting the
+// others.
+func CountAll() []Count {
+	var results []Count
+	for _, c := range counters {
+		if !c.Detect() {
+			continue
+		}
+		n, err := c.Count()
+		if err != nil {
+			continue
+		}
+		results = append(results, Count{Manager: c.Manager(), Count: n})
+	}
+	return results
+}
+
+// lineCount runs cmd and returns the number of non-empty output lines.
+func lineCount(name string, args ...string) (int, error) {
+	output, err := exec.Command(name, args...).Output()
+	if err != nil {
+		return 0, err
+	}
+	lines := strings.Split(strings.TrimRight(string(output), "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return 0, nil
+	}
+	return len(lines), nil
+}
+
+// onPath reports whether name is found on PATH.
+func onPath(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+// dpkgCounter counts packages via dpkg, used by Debian and Ubuntu.
+type dpkgCounter struct{}
+
+func (dpkgCounter) Manager() string { return "dpkg" }
+func (dpkgCounter) Detect() bool    { return runtime.GOOS == "linux" && onPath("dpkg") }
+func (dpkgCounter) Count() (int, error) {
+	return lineCount("dpkg", "--get-selections")
+}
+
+// pacmanCounter counts packages via pacman, used by Arch.
+type pacmanCounter struct{}
+
+func (pacmanCounter) Manager() string { return "pacman" }
+func (pacmanCounter) Detect() bool    { return runtime.GOOS == "linux" && onPath("pacman") }
+func (pacmanCounter) Count() (int, error) {
+	return lineCount("pacman", "-Q")
+}
+
+// rpmCounter counts packages via rpm, used by Fedora/CentOS/RHEL.
+type rpmCounter struct{}
+
+func (rpmCounter) Manager() string { return "rpm" }
+func (rpmCounter) Detect() bool    { return runtime.GOOS == "linux" && onPath("rpm") }
+func (rpmCounter) Count() (int, error) {
+	return lineCount("rpm", "-qa")
+}
+
+// brewCounter counts formulae installed via Homebrew on macOS.
+type brewCounter struct{}
+
+func (brewCounter) Manager() string { return "brew" }
+func (brewCounter) Detect() bool    { return runtime.GOOS == "darwin" && onPath("brew") }
+func (brewCounter) Count() (int, error) {
+	return lineCount("brew", "list", "--formula")
+}
+
+// macAppsCounter counts applications in /Applications on macOS.
+type macAppsCounter struct{}
+
+func (macAppsCounter) Manager() string { return "apps" }
+func (macAppsCounter) Detect() bool    { return runtime.GOOS == "darwin" }
+func (macAppsCounter) Count() (int, error) {
+	return lineCount("ls", "/Applications")
+}
+
+// freebsdPkgCounter counts packages via pkg on FreeBSD.
+type freebsdPkgCounter struct{}
+
+func (freebsdPkgCounter) Manager() string { return "pkg" }
+func (freebsdPkgCounter) Detect() bool    { return runtime.GOOS == "freebsd" && onPath("pkg") }
+func (freebsdPkgCounter) Count() (int, error) {
+	return lineCount("pkg", "info")
+}
+
+// openbsdPkgCounter counts packages via pkg_info on OpenBSD.
+type openbsdPkgCounter struct{}
+
+func (openbsdPkgCounter) Manager() string { return "pkg_info" }
+func (openbsdPkgCounter) Detect() bool    { return runtime.GOOS == "openbsd" && onPath("pkg_info") }
+func (openbsdPkgCounter) Count() (int, error) {
+	return lineCount("pkg_info")
+}
+
+// chocoCounter counts packages via Chocolatey on Windows.
+type chocoCounter struct{}
+
+func (chocoCounter) Manager() string { return "choco" }
+func (chocoCounter) Detect() bool    { return runtime.GOOS == "windows" && onPath("choco") }
+func (chocoCounter) Count() (int, error) {
+	n, err := lineCount("choco", "list", "--local-only")
+	if err != nil {
+		return 0, err
+	}
+	// choco prints a trailing "N packages installed." summary line.
+	if n > 0 {
+		n--
+	}
+	return n, nil
+}
+
+// wingetCounter counts packages via winget on Windows.
+type wingetCounter struct{}
+
+func (wingetCounter) Manager() string { return "winget" }
+func (wingetCounter) Detect() bool    { return runtime.GOOS == "windows" && onPath("winget") }
+func (wingetCounter) Count() (int, error) {
+	return lineCount("winget", "list")
+}
+
+// appxCounter counts packages via the Get-AppxPackage PowerShell cmdlet on
+// Windows.
+type appxCounter struct{}
+
+func (appxCounter) Manager() string { return "appx" }
+func (appxCounter) Detect() bool    { return runtime.GOOS == "windows" && onPath("powershell") }
+func (appxCounter) Count() (int, error) {
+	return lineCount("powershell", "-NoProfile", "-Command", "(Get-AppxPackage).Count")
+}
+
+// flatpakCounter counts Flatpak applications, available on most Linux
+// distributions.
+type flatpakCounter struct{}
+
+func (flatpakCounter) Manager() string { return "flatpak" }
+func (flatpakCounter) Detect() bool    { return onPath("flatpak") }
+func (flatpakCounter) Count() (int, error) {
+	return lineCount("flatpak", "list")
+}
+
+// snapCounter counts installed snaps.
+type snapCounter struct{}
+
+func (snapCounter) Manager() string { return "snap" }
+func (snapCounter) Detect() bool    { return onPath("snap") }
+func (snapCounter) Count() (int, error) {
+	n, err := lineCount("snap", "list")
+	if err != nil {
+		return 0, err
+	}
+	// The first line is a header, not a package.
+	if n > 0 {
+		n--
+	}
+	return n, nil
+}
+
+// nixCounter counts packages installed into the current Nix profile.
+type nixCounter struct{}
+
+func (nixCounter) Manager() string { return "nix" }
+func (nixCounter) Detect() bool    { return onPath("nix-env") }
+func (nixCounter) Count() (int, error) {
+	return lineCount("nix-env", "-q")
+}
+
+// xbpsCounter counts packages via xbps, used by Void Linux.
+type xbpsCounter struct{}
+
+func (xbpsCounter) Manager() string { return "xbps" }
+func (xbpsCounter) Detect() bool    { return onPath("xbps-query") }
+func (xbpsCounter) Count() (int, error) {
+	return lineCount("xbps-query", "-l")
+}
+
+// apkCounter counts packages via apk, used by Alpine Linux.
+type apkCounter struct{}
+
+func (apkCounter) Manager() string { return "apk" }
+func (apkCounter) Detect() bool    { return onPath("apk") }
+func (apkCounter) Count() (int, error) {
+	return lineCount("apk", "info")
+}
+
+// emergeCounter counts packages via Portage, used by Gentoo.
+type emergeCounter struct{}
+
+func (emergeCounter) Manager() string { return "emerge" }
+func (emergeCounter) Detect() bool    { return onPath("emerge") }
+func (emergeCounter) Count() (int, error) {
+	return lineCount("emerge", "--list-installed")
+}
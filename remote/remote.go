@@ -0,0 +1,218 @@
+// Package remote implements sysinfo.Collector over SSH, so go-fetch can
+// report on a cluster of hosts through the same rendering pipeline used
+// for the local machine.
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kevinburke/ssh_config"
+	"github.com/machin0r/go-fetch/sysinfo"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// DialTimeout bounds how long a single SSH connection attempt may take.
+const DialTimeout = 10 * time.Second
+
+// Result pairs a host with the outcome of collecting its system info, so
+// callers can report which hosts were unreachable alongside the data for
+// the ones that weren't.
+type Result struct {
+	Host string
+	Info sysinfo.SystemInfo
+	Err  error
+}
+
+// Collector is a sysinfo.Collector that gathers information from a host by
+// SSHing in and running go-fetch there with -o json. It reuses the user's
+// ~/.ssh/config host aliases and ssh-agent for authentication, the same as
+// the openssh client would.
+type Collector struct {
+	Host string
+}
+
+// Collect implements sysinfo.Collector.
+func (c Collector) Collect() (sysinfo.SystemInfo, error) {
+	client, err := dial(c.Host)
+	if err != nil {
+		return sysinfo.SystemInfo{}, err
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return sysinfo.SystemInfo{}, fmt.Errorf("opening session to %s: %w", c.Host, err)
+	}
+	defer session.Close()
+
+	out, err := session.Output("go-fetch -o json")
+	if err != nil {
+		return sysinfo.SystemInfo{}, fmt.Errorf("running go-fetch on %s: %w", c.Host, err)
+	}
+
+	var info sysinfo.SystemInfo
+	if err := json.Unmarshal(out, &info); err != nil {
+		return sysinfo.SystemInfo{}, fmt.Errorf("parsing go-fetch output from %s: %w", c.Host, err)
+	}
+	return info, nil
+}
+
+// dial opens an SSH connection to host, resolving HostName/User/Port/
+// IdentityFile overrides from ~/.ssh/config the same way the openssh
+// client would, and authenticating via ssh-agent (or the resolved
+// IdentityFile, if ssh-agent doesn't offer a usable key) before verifying
+// the remote key against ~/.ssh/known_hosts.
+func dial(host string) (*ssh.Client, error) {
+	hostKeyCallback, err := knownHostsCallback()
+	if err != nil {
+		return nil, err
+	}
+
+	config := &ssh.ClientConfig{
+		User:            resolveUser(host),
+		Auth:            authMethods(host),
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         DialTimeout,
+	}
+
+	addr := resolveAddr(host)
+
+	client, err := ssh.Dial("tcp", addr, config)
+	if err != nil {
+		return nil, fmt.Errorf("dialing %s: %w", host, err)
+	}
+	return client, nil
+}
+
+// resolveAddr returns the host:port to dial for host, preferring an
+// explicit port already present in host over ~/.ssh/config's Port, and
+// ~/.ssh/config's HostName over the alias itself, falling back to port 22.
+func resolveAddr(host string) string {
+	if h, port, err := net.SplitHostPort(host); err == nil {
+		return net.JoinHostPort(resolveHostName(h), port)
+	}
+
+	port := ssh_config.Get(host, "Port")
+	if port == "" {
+		port = "22"
+	}
+	return net.JoinHostPort(resolveHostName(host), port)
+}
+
+// resolveHostName returns ~/.ssh/config's HostName for host, or host
+// itself if there's no override.
+func resolveHostName(host string) string {
+	if hostName := ssh_config.Get(host, "HostName"); hostName != "" {
+		return hostName
+	}
+	return host
+}
+
+// resolveUser returns ~/.ssh/config's User for host, falling back to the
+// local $USER, the same default the openssh client uses.
+func resolveUser(host string) string {
+	if user := ssh_config.Get(host, "User"); user != "" {
+		return user
+	}
+	return os.Getenv("USER")
+}
+
+// authMethods returns the AuthMethods to try for host: ssh-agent first,
+// then the ~/.ssh/config IdentityFile for host, if one is set and
+// readable.
+func authMethods(host string) []ssh.AuthMethod {
+	methods := []ssh.AuthMethod{agentAuth()}
+	if identity, err := identityFileAuth(host); err == nil {
+		methods = append(methods, identity)
+	}
+	return methods
+}
+
+// agentAuth returns an AuthMethod backed by the running ssh-agent, so
+// go-fetch authenticates the same way the openssh client would.
+func agentAuth() ssh.AuthMethod {
+	sock := os.Getenv("SSH_AUTH_SOCK")
+	if sock == "" {
+		return ssh.PublicKeys()
+	}
+	conn, err := net.Dial("unix", sock)
+	if err != nil {
+		return ssh.PublicKeys()
+	}
+	return ssh.PublicKeysCallback(agent.NewClient(conn).Signers)
+}
+
+// identityFileAuth reads ~/.ssh/config's IdentityFile for host and returns
+// an AuthMethod for the key it names.
+func identityFileAuth(host string) (ssh.AuthMethod, error) {
+	path := ssh_config.Get(host, "IdentityFile")
+	if path == "" {
+		return nil, fmt.Errorf("no IdentityFile configured for %s", host)
+	}
+
+	if home, err := os.UserHomeDir(); err == nil && strings.HasPrefix(path, "~/") {
+		path = filepath.Join(home, strings.TrimPrefix(path, "~/"))
+	}
+
+	key, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return ssh.PublicKeys(signer), nil
+}
+
+// knownHostsCallback builds a HostKeyCallback from the user's
+// ~/.ssh/known_hosts file.
+func knownHostsCallback() (ssh.HostKeyCallback, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return knownhosts.New(filepath.Join(home, ".ssh", "known_hosts"))
+}
+
+// Gather collects system info from every host concurrently, using a
+// worker pool bounded by workers, and returns one Result per host in the
+// same order hosts was given.
+func Gather(hosts []string, workers int) []Result {
+	if workers <= 0 || workers > len(hosts) {
+		workers = len(hosts)
+	}
+
+	results := make([]Result, len(hosts))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				host := hosts[i]
+				info, err := (Collector{Host: host}).Collect()
+				results[i] = Result{Host: host, Info: info, Err: err}
+			}
+		}()
+	}
+
+	for i := range hosts {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
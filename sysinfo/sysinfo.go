@@ -0,0 +1,154 @@
+// Package sysinfo gathers host, hardware and software information into a
+// single SystemInfo value that can be rendered for humans or serialised
+// for machine consumption (JSON/YAML).
+package sysinfo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/machin0r/go-fetch/gpu"
+	"github.com/machin0r/go-fetch/packages"
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// CPU describes the host's processor.
+type CPU struct {
+	Model string  `json:"model" yaml:"model"`
+	Cores int     `json:"cores" yaml:"cores"`
+	GHz   float64 `json:"ghz" yaml:"ghz"`
+}
+
+// Memory describes current memory usage, in bytes.
+type Memory struct {
+	Used  uint64 `json:"used" yaml:"used"`
+	Total uint64 `json:"total" yaml:"total"`
+}
+
+// Packages describes the installed package count as reported by a single
+// package manager detected on the host. A host can report more than one,
+// e.g. dpkg alongside flatpak and snap.
+type Packages struct {
+	Manager string `json:"manager" yaml:"manager"`
+	Count   int    `json:"count" yaml:"count"`
+}
+
+// SystemInfo is the full set of information go-fetch reports about a host.
+type SystemInfo struct {
+	Host          string     `json:"host" yaml:"host"`
+	OS            string     `json:"os" yaml:"os"`
+	Kernel        string     `json:"kernel" yaml:"kernel"`
+	Uptime        string     `json:"uptime" yaml:"uptime"`
+	UptimeSeconds uint64     `json:"uptime_seconds" yaml:"uptime_seconds"`
+	Shell         string     `json:"shell" yaml:"shell"`
+	CPU           CPU        `json:"cpu" yaml:"cpu"`
+	GPUs          []gpu.GPU  `json:"gpus" yaml:"gpus"`
+	Memory        Memory     `json:"memory" yaml:"memory"`
+	Packages      []Packages `json:"packages" yaml:"packages"`
+}
+
+// Collector gathers a SystemInfo snapshot from some host, local or remote.
+// Implementations must be safe to call concurrently, since multi-host
+// callers (e.g. the remote package) run collectors from a worker pool.
+type Collector interface {
+	Collect() (SystemInfo, error)
+}
+
+// LocalCollector is a Collector that gathers information about the machine
+// go-fetch is running on.
+type LocalCollector struct{}
+
+// Collect implements Collector by deferring to the package-level Collect
+// function.
+func (LocalCollector) Collect() (SystemInfo, error) {
+	return Collect()
+}
+
+// Collect gathers a SystemInfo snapshot of the local host. Collection
+// errors for individual fields (GPU, package count) are not fatal; they
+// are reflected in the returned value instead (e.g. an empty GPUs slice
+// or a Packages.Manager of "unknown").
+func Collect() (SystemInfo, error) {
+	hostname, _ := os.Hostname()
+	osInfo, err := host.Info()
+	if err != nil {
+		return SystemInfo{}, err
+	}
+	memInfo, err := mem.VirtualMemory()
+	if err != nil {
+		return SystemInfo{}, err
+	}
+
+	cpuModel, cpuCores, cpuGHz := getCPUInfo()
+
+	info := SystemInfo{
+		Host:          hostname,
+		OS:            fmt.Sprintf("%s %s", osInfo.Platform, osInfo.PlatformVersion),
+		Kernel:        osInfo.KernelVersion,
+		Uptime:        formatUptime(osInfo.Uptime),
+		UptimeSeconds: osInfo.Uptime,
+		Shell:         filepath.Base(os.Getenv("SHELL")),
+		CPU: CPU{
+			Model: cpuModel,
+			Cores: cpuCores,
+			GHz:   cpuGHz,
+		},
+		Memory: Memory{
+			Used:  memInfo.Used,
+			Total: memInfo.Total,
+		},
+		GPUs:     []gpu.GPU{},
+		Packages: []Packages{},
+	}
+
+	if detected := gpu.Detect(); detected != nil {
+		info.GPUs = detected
+	}
+
+	for _, c := range packages.CountAll() {
+		info.Packages = append(info.Packages, Packages{Manager: c.Manager, Count: c.Count})
+	}
+
+	return info, nil
+}
+
+// formatUptime converts the uptime in seconds to a human-readable string
+// in the format of "Xd Yh Zm" (days, hours, minutes).
+func formatUptime(uptime uint64) string {
+	duration := time.Duration(uptime) * time.Second
+	days := int(duration.Hours() / 24)
+	hours := int(duration.Hours()) % 24
+	minutes := int(duration.Minutes()) % 60
+
+	return fmt.Sprintf("%dd %dh %dm", days, hours, minutes)
+}
+
+// getCPUInfo retrieves CPU information including model name, core count, and
+// clock speed. It falls back to runtime.NumCPU() for core count if gopsutil
+// fails to provide the information.
+func getCPUInfo() (model string, cores int, speed float64) {
+	cpuInfo, err := cpu.Info()
+	if err != nil || len(cpuInfo) == 0 {
+		// Fallback to runtime package for core count
+		cores = runtime.NumCPU()
+		model = "Unknown"
+		speed = 0.0
+		return
+	}
+
+	model = cpuInfo[0].ModelName
+	cores = runtime.NumCPU()      // Use runtime.NumCPU() for consistent logical core count
+	speed = cpuInfo[0].Mhz / 1000 // Convert to GHz
+
+	// If cores is 0, fallback to runtime package
+	if cores == 0 {
+		cores = runtime.NumCPU()
+	}
+
+	return
+}